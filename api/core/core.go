@@ -16,8 +16,12 @@ package core
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc/codes"
 
@@ -30,10 +34,23 @@ type (
 		SpanContext
 	}
 
+	// TraceID is a unique identity of a trace, matching the 16-byte
+	// trace-id field of the W3C Trace Context specification.
+	TraceID [16]byte
+
+	// SpanID is a unique identity of a span within a trace, matching the
+	// 8-byte parent-id field of the W3C Trace Context specification.
+	SpanID [8]byte
+
+	// SpanContext contains basic information about the span - its
+	// TraceID, SpanID, flags (currently only the sampled bit) and the
+	// TraceState carried across process boundaries per the W3C Trace
+	// Context specification.
 	SpanContext struct {
-		TraceIDHigh uint64
-		TraceIDLow  uint64
-		SpanID      uint64
+		TraceID    TraceID
+		SpanID     SpanID
+		TraceFlags byte
+		TraceState TraceState
 	}
 
 	EventID uint64
@@ -99,7 +116,18 @@ type (
 		String  string
 		Bytes   []byte
 
-		// TODO Lazy value type?
+		// lazy holds the closure and memoized result for a LAZY Value.
+		// It is nil for every other ValueType.
+		lazy *lazyValue
+	}
+
+	// lazyValue is kept out of Value itself so Value can still be
+	// copied freely; once is embedded by pointer so the memoized Emit
+	// result is shared across every copy of the Value that produced it.
+	lazyValue struct {
+		once sync.Once
+		fn   func() Value
+		str  string
 	}
 
 	MutatorOp int
@@ -111,9 +139,16 @@ type (
 	}
 
 	MeasureMetadata struct {
-		MaxHops int // -1 == infinite, 0 == do not propagate
-
-		// TODO time to live?
+		MaxHops int           // -1 == infinite, 0 == do not propagate
+		TTL     time.Duration // -1 == infinite, 0 == do not propagate
+
+		// clock is the propagation clock reading MeasureMetadata was
+		// stamped with, used together with TTL to decide expiry. It is
+		// kept as a time.Time (not an extracted int64) so Expired and
+		// RemainingTTL can use time.Time's monotonic reading instead of
+		// wall-clock subtraction, which would misbehave across NTP
+		// steps or manual clock changes within a process.
+		clock time.Time
 	}
 )
 
@@ -128,11 +163,20 @@ const (
 	FLOAT64
 	STRING
 	BYTES
+	LAZY
 
 	INSERT MutatorOp = iota
 	UPDATE
 	UPSERT
 	DELETE
+
+	// FlagsSampled is set on SpanContext.TraceFlags when the span has
+	// been sampled, matching the W3C Trace Context "sampled" flag.
+	FlagsSampled = byte(0x01)
+
+	// traceParentVersion is the only trace-context version this package
+	// knows how to parse or emit.
+	traceParentVersion = "00"
 )
 
 var (
@@ -141,27 +185,96 @@ var (
 	INVALID_SPAN_CONTEXT = SpanContext{}
 )
 
+// String returns the lower-case hex encoding of the TraceID.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// String returns the lower-case hex encoding of the SpanID.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
 func (sc SpanContext) HasTraceID() bool {
-	return sc.TraceIDHigh != 0 || sc.TraceIDLow != 0
+	return sc.TraceID != TraceID{}
 }
 
 func (sc SpanContext) HasSpanID() bool {
-	return sc.SpanID != 0
+	return sc.SpanID != SpanID{}
 }
 
 func (sc SpanContext) SpanIDString() string {
-	p := fmt.Sprintf("%.16x", sc.SpanID)
-	return p[0:3] + ".." + p[13:16]
+	return sc.SpanID.String()
 }
 
 func (sc SpanContext) TraceIDString() string {
-	p1 := fmt.Sprintf("%.16x", sc.TraceIDHigh)
-	p2 := fmt.Sprintf("%.16x", sc.TraceIDLow)
-	return p1[0:3] + ".." + p2[13:16]
+	return sc.TraceID.String()
+}
+
+// IsSampled reports whether the sampled bit is set in TraceFlags.
+func (sc SpanContext) IsSampled() bool {
+	return sc.TraceFlags&FlagsSampled == FlagsSampled
+}
+
+// TraceParent renders the SpanContext in the W3C "traceparent" header
+// format: "00-<trace-id>-<span-id>-<flags>".
+func (sc SpanContext) TraceParent() string {
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion, sc.TraceID, sc.SpanID, sc.TraceFlags)
+}
+
+// FromTraceParent parses a W3C "traceparent" header value into a
+// SpanContext. Only version "00" is supported; unknown versions are
+// rejected per the specification rather than best-effort parsed.
+func FromTraceParent(traceParent string) (SpanContext, error) {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) != 4 {
+		return INVALID_SPAN_CONTEXT, fmt.Errorf("invalid traceparent: %q", traceParent)
+	}
+	if parts[0] != traceParentVersion {
+		return INVALID_SPAN_CONTEXT, fmt.Errorf("unsupported traceparent version: %q", parts[0])
+	}
+
+	var sc SpanContext
+
+	traceID, err := hex.DecodeString(parts[1])
+	if err != nil || len(traceID) != len(sc.TraceID) {
+		return INVALID_SPAN_CONTEXT, fmt.Errorf("invalid traceparent trace-id: %q", parts[1])
+	}
+	copy(sc.TraceID[:], traceID)
+
+	spanID, err := hex.DecodeString(parts[2])
+	if err != nil || len(spanID) != len(sc.SpanID) {
+		return INVALID_SPAN_CONTEXT, fmt.Errorf("invalid traceparent parent-id: %q", parts[2])
+	}
+	copy(sc.SpanID[:], spanID)
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return INVALID_SPAN_CONTEXT, fmt.Errorf("invalid traceparent trace-flags: %q", parts[3])
+	}
+	sc.TraceFlags = flags[0]
+
+	if !sc.HasTraceID() || !sc.HasSpanID() {
+		return INVALID_SPAN_CONTEXT, fmt.Errorf("invalid traceparent: %q", traceParent)
+	}
+
+	return sc, nil
+}
+
+// LazyValue returns a Value that defers computing its contents until
+// the first call to Emit, which then memoizes the result so later
+// calls are allocation-free.
+func LazyValue(fn func() Value) Value {
+	return Value{Type: LAZY, lazy: &lazyValue{fn: fn}}
 }
 
-// TODO make this a lazy one-time conversion.
 func (v Value) Emit() string {
+	if v.Type == LAZY {
+		v.lazy.once.Do(func() {
+			v.lazy.str = v.lazy.fn().Emit()
+		})
+		return v.lazy.str
+	}
 	switch v.Type {
 	case BOOL:
 		return fmt.Sprint(v.Bool)
@@ -179,8 +292,84 @@ func (v Value) Emit() string {
 	return "unknown"
 }
 
+// WithMaxHops sets m's MaxHops, stamping its propagation clock via
+// propagationClock if it hasn't been stamped yet so the returned
+// Mutator's MeasureMetadata is immediately valid for Expired checks.
 func (m Mutator) WithMaxHops(hops int) Mutator {
 	m.MaxHops = hops
+	if m.clock.IsZero() {
+		m.clock = propagationClock()
+	}
+	return m
+}
+
+// WithTTL sets m's TTL, stamping its propagation clock via
+// propagationClock if it hasn't been stamped yet. Without this, a
+// Mutator built solely through the fluent setters (rather than
+// NewMeasureMetadata) would have a zero clock, making Expired always
+// report true.
+func (m Mutator) WithTTL(ttl time.Duration) Mutator {
+	m.TTL = ttl
+	if m.clock.IsZero() {
+		m.clock = propagationClock()
+	}
+	return m
+}
+
+// propagationClock returns the current propagation clock reading, used
+// to stamp MeasureMetadata so its TTL can be evaluated on later hops.
+// It returns a time.Time (not an extracted field of one) so later
+// comparisons can use its monotonic reading rather than wall-clock
+// subtraction.
+func propagationClock() time.Time {
+	return time.Now()
+}
+
+// NewMeasureMetadata returns MeasureMetadata stamped with the current
+// propagation clock reading.
+func NewMeasureMetadata(maxHops int, ttl time.Duration) MeasureMetadata {
+	return MeasureMetadata{MaxHops: maxHops, TTL: ttl, clock: propagationClock()}
+}
+
+// Expired reports whether m's TTL has elapsed since it was stamped by
+// NewMeasureMetadata. A TTL of -1 never expires; a TTL of 0 is always
+// expired, matching the do-not-propagate convention used by MaxHops.
+func (m MeasureMetadata) Expired() bool {
+	switch {
+	case m.TTL < 0:
+		return false
+	case m.TTL == 0:
+		return true
+	default:
+		return propagationClock().Sub(m.clock) > m.TTL
+	}
+}
+
+// RemainingTTL returns the TTL remaining since m was stamped, i.e. TTL
+// minus elapsed time, floored at zero. A TTL of -1 (infinite) or 0
+// (do not propagate) is returned unchanged, since neither decays.
+func (m MeasureMetadata) RemainingTTL() time.Duration {
+	if m.TTL <= 0 {
+		return m.TTL
+	}
+	remaining := m.TTL - propagationClock().Sub(m.clock)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// HopsExpired reports whether m's hop budget has been exhausted.
+func (m MeasureMetadata) HopsExpired() bool {
+	return m.MaxHops == 0
+}
+
+// DecrementHops returns a copy of m with MaxHops reduced by one. A
+// MaxHops of -1 (infinite) or 0 (already exhausted) is left unchanged.
+func (m MeasureMetadata) DecrementHops() MeasureMetadata {
+	if m.MaxHops > 0 {
+		m.MaxHops--
+	}
 	return m
 }
 
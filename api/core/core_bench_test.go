@@ -0,0 +1,42 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "testing"
+
+// BenchmarkValueEmit compares repeat Emit calls against an eager Value
+// (which re-formats the float on every call) and a LAZY Value (which
+// formats once and then returns the memoized string).
+func BenchmarkValueEmit(b *testing.B) {
+	b.Run("eager", func(b *testing.B) {
+		v := Value{Type: FLOAT64, Float64: 3.14159}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = v.Emit()
+		}
+	})
+
+	b.Run("lazy", func(b *testing.B) {
+		v := LazyValue(func() Value {
+			return Value{Type: FLOAT64, Float64: 3.14159}
+		})
+		_ = v.Emit() // memoize before the timed loop
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = v.Emit()
+		}
+	})
+}
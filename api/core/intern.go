@@ -0,0 +1,95 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"container/list"
+	"sync"
+)
+
+// defaultInternCapacity bounds the number of distinct strings kept
+// alive by InternedString. High-cardinality label writers (e.g.
+// per-request URL paths) would otherwise grow this cache unboundedly.
+const defaultInternCapacity = 4096
+
+// stringInterner deduplicates repeated string values so that equal
+// strings share a single backing array. Lookups go through sync.Map;
+// the eviction order is tracked separately under a mutex since
+// sync.Map has no notion of recency, so a cache hit still takes the
+// mutex to move its entry to the front.
+type stringInterner struct {
+	mu       sync.Mutex
+	order    *list.List
+	elements sync.Map // string -> *list.Element, Element.Value is string
+	capacity int
+}
+
+func newStringInterner(capacity int) *stringInterner {
+	return &stringInterner{
+		order:    list.New(),
+		capacity: capacity,
+	}
+}
+
+func (in *stringInterner) intern(s string) string {
+	if elem, ok := in.elements.Load(s); ok {
+		in.touch(elem.(*list.Element))
+		return elem.(*list.Element).Value.(string)
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	// Another goroutine may have inserted s while we waited for the lock.
+	if elem, ok := in.elements.Load(s); ok {
+		in.order.MoveToFront(elem.(*list.Element))
+		return elem.(*list.Element).Value.(string)
+	}
+
+	elem := in.order.PushFront(s)
+	in.elements.Store(s, elem)
+
+	if in.order.Len() > in.capacity {
+		oldest := in.order.Back()
+		in.order.Remove(oldest)
+		in.elements.Delete(oldest.Value.(string))
+	}
+
+	return s
+}
+
+func (in *stringInterner) touch(elem *list.Element) {
+	in.mu.Lock()
+	in.order.MoveToFront(elem)
+	in.mu.Unlock()
+}
+
+var defaultInterner = newStringInterner(defaultInternCapacity)
+
+// InternedString returns a canonical copy of s, deduplicating against
+// a bounded LRU cache shared across callers, so that repeated calls
+// with an equal string share one backing array instead of each
+// allocating its own copy.
+//
+// Dedup is by exact string equality, so this only pays off when s is
+// drawn from a small, repetitive set of values (e.g. a status string).
+// It does not help truly high-cardinality values such as a per-request
+// URL path: almost every call sees a distinct string, so the cache
+// never gets a hit and every call still pays for the lock. Normalize
+// values like that (e.g. to a route template) before interning them,
+// or skip interning entirely.
+func InternedString(s string) string {
+	return defaultInterner.intern(s)
+}
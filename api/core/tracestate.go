@@ -0,0 +1,194 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TraceStateEntry is a single key/value pair carried by a TraceState, as
+// defined by the W3C Trace Context "tracestate" header.
+type TraceStateEntry struct {
+	Key   string
+	Value string
+}
+
+// TraceState models the W3C "tracestate" header: an ordered list of
+// vendor-specific key/value pairs that travels alongside a SpanContext.
+// The zero value is an empty TraceState. TraceState is immutable; all
+// mutating methods return a new value.
+type TraceState struct {
+	// entries is ordered left-to-right as it appears on the wire, with
+	// the most recently inserted/updated entry first.
+	entries []TraceStateEntry
+}
+
+const (
+	maxTraceStateEntries = 32
+	maxTraceStateKeyLen  = 256
+	maxTraceStateValLen  = 256
+)
+
+// NewTraceState returns a TraceState built from entries, in the given
+// order. Callers are expected to have already validated the entries
+// (e.g. via ParseTraceState); invalid or duplicate keys are silently
+// dropped to keep the constructor infallible.
+func NewTraceState(entries ...TraceStateEntry) TraceState {
+	// Insert places each entry at the front, so insert in reverse to
+	// end up with entries[0] left-most; for a duplicate key the first
+	// occurrence is inserted last and wins.
+	var ts TraceState
+	for i := len(entries) - 1; i >= 0; i-- {
+		ts = ts.Insert(entries[i].Key, entries[i].Value)
+	}
+	return ts
+}
+
+// Len returns the number of entries in the TraceState.
+func (ts TraceState) Len() int {
+	return len(ts.entries)
+}
+
+// Get returns the value associated with key and whether it was present.
+func (ts TraceState) Get(key string) (string, bool) {
+	for _, e := range ts.entries {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	return "", false
+}
+
+// Insert returns a new TraceState with key set to value. If key is
+// already present its prior position is dropped, and the new entry is
+// placed at the front, matching the W3C requirement that updated
+// entries move to the left-most position.
+func (ts TraceState) Insert(key, value string) TraceState {
+	if !isValidTraceStateKey(key) || !isValidTraceStateValue(value) {
+		return ts
+	}
+	entries := make([]TraceStateEntry, 0, len(ts.entries)+1)
+	entries = append(entries, TraceStateEntry{Key: key, Value: value})
+	for _, e := range ts.entries {
+		if e.Key == key {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) > maxTraceStateEntries {
+		entries = entries[:maxTraceStateEntries]
+	}
+	return TraceState{entries: entries}
+}
+
+// Delete returns a new TraceState with key removed, if present.
+func (ts TraceState) Delete(key string) TraceState {
+	entries := make([]TraceStateEntry, 0, len(ts.entries))
+	for _, e := range ts.entries {
+		if e.Key == key {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return TraceState{entries: entries}
+}
+
+// String serializes the TraceState into the wire format used by the
+// "tracestate" header: comma-separated "key=value" pairs.
+func (ts TraceState) String() string {
+	if len(ts.entries) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for i, e := range ts.entries {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(e.Key)
+		sb.WriteByte('=')
+		sb.WriteString(e.Value)
+	}
+	return sb.String()
+}
+
+// ParseTraceState parses the value of a "tracestate" header into a
+// TraceState. Malformed entries are rejected rather than silently
+// dropped, since an invalid tracestate must be rejected wholesale per
+// the W3C specification.
+func ParseTraceState(s string) (TraceState, error) {
+	if s == "" {
+		return TraceState{}, nil
+	}
+
+	members := strings.Split(s, ",")
+	if len(members) > maxTraceStateEntries {
+		return TraceState{}, fmt.Errorf("tracestate: too many entries (%d)", len(members))
+	}
+
+	var ts TraceState
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+		kv := strings.SplitN(member, "=", 2)
+		if len(kv) != 2 {
+			return TraceState{}, fmt.Errorf("tracestate: invalid member %q", member)
+		}
+		key, value := kv[0], kv[1]
+		if !isValidTraceStateKey(key) {
+			return TraceState{}, fmt.Errorf("tracestate: invalid key %q", key)
+		}
+		if !isValidTraceStateValue(value) {
+			return TraceState{}, fmt.Errorf("tracestate: invalid value %q", value)
+		}
+		if seen[key] {
+			return TraceState{}, fmt.Errorf("tracestate: duplicate key %q", key)
+		}
+		seen[key] = true
+		ts.entries = append(ts.entries, TraceStateEntry{Key: key, Value: value})
+	}
+	return ts, nil
+}
+
+func isValidTraceStateKey(key string) bool {
+	if len(key) == 0 || len(key) > maxTraceStateKeyLen {
+		return false
+	}
+	for i, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		case (r == '_' || r == '-' || r == '*' || r == '/' || r == '@') && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isValidTraceStateValue(value string) bool {
+	if len(value) == 0 || len(value) > maxTraceStateValLen {
+		return false
+	}
+	for _, r := range value {
+		if r == ',' || r == '=' || r < 0x20 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestTraceStateParseStringRoundTrip checks that a valid tracestate
+// header survives a Parse/String round trip, with the header's
+// left-to-right member order preserved.
+func TestTraceStateParseStringRoundTrip(t *testing.T) {
+	const header = "vendor1=value1,vendor2=value2"
+
+	ts, err := ParseTraceState(header)
+	if err != nil {
+		t.Fatalf("ParseTraceState(%q) returned error: %v", header, err)
+	}
+	if got, want := ts.Len(), 2; got != want {
+		t.Fatalf("Len() = %d, want %d", got, want)
+	}
+	if got := ts.String(); got != header {
+		t.Errorf("String() = %q, want %q", got, header)
+	}
+
+	v, ok := ts.Get("vendor2")
+	if !ok || v != "value2" {
+		t.Errorf("Get(%q) = (%q, %v), want (%q, true)", "vendor2", v, ok, "value2")
+	}
+}
+
+// TestTraceStateInsertMovesToFront checks that Insert-ing an existing
+// key updates its value and moves it to the left-most position, per
+// the W3C tracestate mutation rules.
+func TestTraceStateInsertMovesToFront(t *testing.T) {
+	ts := NewTraceState(
+		TraceStateEntry{Key: "vendor1", Value: "value1"},
+		TraceStateEntry{Key: "vendor2", Value: "value2"},
+	)
+
+	ts = ts.Insert("vendor2", "updated")
+
+	if got, want := ts.String(), "vendor2=updated,vendor1=value1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// TestTraceStateDelete checks that Delete removes the named entry and
+// leaves the rest untouched.
+func TestTraceStateDelete(t *testing.T) {
+	ts := NewTraceState(
+		TraceStateEntry{Key: "vendor1", Value: "value1"},
+		TraceStateEntry{Key: "vendor2", Value: "value2"},
+	)
+
+	ts = ts.Delete("vendor1")
+
+	if _, ok := ts.Get("vendor1"); ok {
+		t.Errorf("Get(%q) found entry after Delete", "vendor1")
+	}
+	if got, want := ts.String(), "vendor2=value2"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// TestTraceStateInsertCapsEntries checks that Insert truncates at
+// maxTraceStateEntries rather than growing the list unboundedly.
+func TestTraceStateInsertCapsEntries(t *testing.T) {
+	var ts TraceState
+	for i := 0; i < maxTraceStateEntries+5; i++ {
+		ts = ts.Insert(keyForIndex(i), "v")
+	}
+	if got := ts.Len(); got != maxTraceStateEntries {
+		t.Errorf("Len() = %d, want %d", got, maxTraceStateEntries)
+	}
+}
+
+func keyForIndex(i int) string {
+	return "k" + string(rune('a'+i%26)) + string(rune('a'+(i/26)%26))
+}
+
+// TestParseTraceStateMalformed checks that malformed tracestate headers
+// are rejected wholesale, rather than silently dropping the bad member.
+func TestParseTraceStateMalformed(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+	}{
+		{"missing equals", "novalue"},
+		{"invalid key", "Invalid=value"},
+		{"invalid value character", "vendor1=has\x01control"},
+		{"duplicate key", "vendor1=value1,vendor1=value2"},
+		{"too many entries", strings.Repeat("a=b,", maxTraceStateEntries+1)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseTraceState(tc.header); err == nil {
+				t.Errorf("ParseTraceState(%q) returned nil error, want non-nil", tc.header)
+			}
+		})
+	}
+}
+
+// TestParseTraceStateEmpty checks that an empty header parses to an
+// empty, error-free TraceState.
+func TestParseTraceStateEmpty(t *testing.T) {
+	ts, err := ParseTraceState("")
+	if err != nil {
+		t.Fatalf("ParseTraceState(\"\") returned error: %v", err)
+	}
+	if got := ts.Len(); got != 0 {
+		t.Errorf("Len() = %d, want 0", got)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package distributedcontext implements baggage: a set of key/value
+// pairs that travels alongside a request, subject to a hop budget and
+// a time-to-live, independent of any particular trace or span.
+package distributedcontext
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+// Entry is a single baggage item: the KeyValue carried, together with
+// the MeasureMetadata governing how far and for how long it propagates.
+type Entry struct {
+	core.KeyValue
+	core.MeasureMetadata
+}
+
+// Map is an immutable snapshot of baggage entries, keyed by name. The
+// zero value is an empty Map.
+type Map struct {
+	entries map[string]Entry
+}
+
+// NewMap returns a Map built by applying mutators to an empty baggage
+// set, in order.
+func NewMap(mutators ...core.Mutator) Map {
+	return Map{}.Apply(mutators...)
+}
+
+// Apply returns a new Map reflecting mutators applied in order against
+// m, implementing INSERT/UPDATE/UPSERT/DELETE semantics:
+//
+//   - INSERT only takes effect if the key is absent.
+//   - UPDATE only takes effect if the key is already present.
+//   - UPSERT always takes effect.
+//   - DELETE always removes the key, if present.
+func (m Map) Apply(mutators ...core.Mutator) Map {
+	entries := m.clone()
+	for _, mut := range mutators {
+		name := mut.KeyValue.Key.Name()
+		_, present := entries[name]
+
+		switch mut.MutatorOp {
+		case core.INSERT:
+			if present {
+				continue
+			}
+		case core.UPDATE:
+			if !present {
+				continue
+			}
+		case core.UPSERT:
+			// always applies
+		case core.DELETE:
+			delete(entries, name)
+			continue
+		default:
+			continue
+		}
+
+		entries[name] = Entry{KeyValue: mut.KeyValue, MeasureMetadata: mut.MeasureMetadata}
+	}
+	return Map{entries: entries}
+}
+
+func (m Map) clone() map[string]Entry {
+	entries := make(map[string]Entry, len(m.entries))
+	for k, v := range m.entries {
+		entries[k] = v
+	}
+	return entries
+}
+
+// Value returns the core.Value stored under k, if present.
+func (m Map) Value(k core.Key) (core.Value, bool) {
+	e, ok := m.entries[k.Name()]
+	if !ok {
+		return core.Value{}, false
+	}
+	return e.Value, true
+}
+
+// Len returns the number of entries in the Map.
+func (m Map) Len() int {
+	return len(m.entries)
+}
+
+// Foreach calls f for every Entry in the Map, stopping early if f
+// returns false.
+func (m Map) Foreach(f func(Entry) bool) {
+	for _, e := range m.entries {
+		if !f(e) {
+			return
+		}
+	}
+}
+
+type mapContextKeyType struct{}
+
+var mapContextKey mapContextKeyType
+
+// ContextWithMap returns a copy of ctx carrying m.
+func ContextWithMap(ctx context.Context, m Map) context.Context {
+	return context.WithValue(ctx, mapContextKey, m)
+}
+
+// NewContext returns a copy of ctx whose Map is the result of applying
+// mutators to the Map already in ctx (or an empty Map, if none).
+func NewContext(ctx context.Context, mutators ...core.Mutator) context.Context {
+	return ContextWithMap(ctx, FromContext(ctx).Apply(mutators...))
+}
+
+// FromContext returns the Map carried by ctx, or an empty Map if none
+// was set.
+func FromContext(ctx context.Context) Map {
+	if m, ok := ctx.Value(mapContextKey).(Map); ok {
+		return m
+	}
+	return Map{}
+}
@@ -0,0 +1,153 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package distributedcontext
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/key"
+	"github.com/open-telemetry/opentelemetry-go/api/propagation"
+)
+
+const baggageHeader = "baggage"
+
+// Baggage implements the W3C "baggage" header propagation format:
+// comma-separated "key=value;ttl=<seconds>;hops=<n>" members.
+//
+// Inject decrements each entry's hop budget before writing it, since
+// crossing this propagation boundary consumes one hop. Extract drops
+// any entry whose hop budget or TTL has already been exhausted, rather
+// than carrying dead baggage forward.
+type Baggage struct{}
+
+var _ propagation.TextMapPropagator = Baggage{}
+
+// Inject writes the Map found in ctx into carrier's "baggage" header.
+func (Baggage) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	m := FromContext(ctx)
+	if m.Len() == 0 {
+		return
+	}
+
+	var members []string
+	m.Foreach(func(e Entry) bool {
+		if e.HopsExpired() || e.Expired() {
+			return true
+		}
+		e.MeasureMetadata = e.MeasureMetadata.DecrementHops()
+		members = append(members, encodeEntry(e))
+		return true
+	})
+
+	if len(members) > 0 {
+		carrier.Set(baggageHeader, strings.Join(members, ","))
+	}
+}
+
+// Extract reads carrier's "baggage" header into a Map, dropping any
+// entry whose hop budget or TTL has already been exhausted, and returns
+// a context carrying the result.
+func (Baggage) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	m := NewMap()
+
+	for _, member := range strings.Split(carrier.Get(baggageHeader), ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+		e, ok := decodeEntry(member)
+		if !ok || e.HopsExpired() || e.Expired() {
+			continue
+		}
+		m = m.Apply(core.Mutator{
+			MutatorOp:       core.UPSERT,
+			KeyValue:        e.KeyValue,
+			MeasureMetadata: e.MeasureMetadata,
+		})
+	}
+
+	return ContextWithMap(ctx, m)
+}
+
+// Fields returns the carrier keys used by Baggage.
+func (Baggage) Fields() []string {
+	return []string{baggageHeader}
+}
+
+func encodeEntry(e Entry) string {
+	member := e.Key.Name() + "=" + url.QueryEscape(e.Value.Emit())
+	member += ";" + encodeDuration("ttl", e.RemainingTTL())
+	member += ";" + encodeHops(e.MaxHops)
+	return member
+}
+
+func encodeDuration(property string, ttl time.Duration) string {
+	if ttl < 0 {
+		return property + "=-1"
+	}
+	return fmt.Sprintf("%s=%d", property, int64(ttl/time.Second))
+}
+
+func encodeHops(maxHops int) string {
+	return fmt.Sprintf("hops=%d", maxHops)
+}
+
+func decodeEntry(member string) (Entry, bool) {
+	parts := strings.Split(member, ";")
+
+	kv := strings.SplitN(parts[0], "=", 2)
+	if len(kv) != 2 {
+		return Entry{}, false
+	}
+	name := strings.TrimSpace(kv[0])
+	value, err := url.QueryUnescape(strings.TrimSpace(kv[1]))
+	if err != nil {
+		return Entry{}, false
+	}
+
+	meta := core.NewMeasureMetadata(-1, -1)
+	for _, prop := range parts[1:] {
+		prop = strings.TrimSpace(prop)
+		kv := strings.SplitN(prop, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "ttl":
+			if seconds, err := strconv.ParseInt(strings.TrimSpace(kv[1]), 10, 64); err == nil {
+				if seconds < 0 {
+					meta = core.NewMeasureMetadata(meta.MaxHops, -1)
+				} else {
+					meta = core.NewMeasureMetadata(meta.MaxHops, time.Duration(seconds)*time.Second)
+				}
+			}
+		case "hops":
+			if hops, err := strconv.Atoi(strings.TrimSpace(kv[1])); err == nil {
+				meta = core.NewMeasureMetadata(hops, meta.TTL)
+			}
+		}
+	}
+
+	return Entry{
+		KeyValue:        key.New(name).String(value),
+		MeasureMetadata: meta,
+	}, true
+}
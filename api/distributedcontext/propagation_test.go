@@ -0,0 +1,123 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package distributedcontext
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/key"
+)
+
+// mapCarrier is a minimal propagation.TextMapCarrier backed by a map,
+// for use in tests that don't need real HTTP headers or gRPC metadata.
+type mapCarrier map[string]string
+
+func (c mapCarrier) Get(k string) string { return c[k] }
+func (c mapCarrier) Set(k, v string)     { c[k] = v }
+
+// FuzzDecodeEntry feeds arbitrary "baggage" member strings to
+// decodeEntry, which must never panic regardless of input.
+func FuzzDecodeEntry(f *testing.F) {
+	f.Add("key=value;ttl=60;hops=3")
+	f.Add("key=value")
+	f.Add("key=")
+	f.Add("=value")
+	f.Add("key=value;ttl=-1;hops=-1")
+	f.Add("key=value;ttl=not-a-number;hops=also-not-a-number")
+	f.Add("key=val%2Fue;hops=0")
+	f.Add(";;;")
+
+	f.Fuzz(func(t *testing.T, member string) {
+		// decodeEntry must handle arbitrary input without panicking;
+		// its return value isn't otherwise constrained here.
+		_, _ = decodeEntry(member)
+	})
+}
+
+// TestBaggageInjectExtractRoundTrip checks that entries surviving
+// Inject (those with a remaining hop budget and unexpired TTL) are
+// recoverable via Extract, with MaxHops decremented by exactly one hop
+// for having crossed the propagation boundary.
+func TestBaggageInjectExtractRoundTrip(t *testing.T) {
+	cases := []struct {
+		name    string
+		maxHops int
+		ttl     time.Duration
+	}{
+		{"infinite hops and ttl", -1, -1},
+		{"bounded hops and ttl", 3, time.Hour},
+		{"two hops remaining", 2, time.Minute},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := NewContext(context.Background(), core.Mutator{
+				MutatorOp:       core.UPSERT,
+				KeyValue:        key.New("user.id").String("abc-123"),
+				MeasureMetadata: core.NewMeasureMetadata(tc.maxHops, tc.ttl),
+			})
+
+			carrier := mapCarrier{}
+			Baggage{}.Inject(ctx, carrier)
+
+			out := Baggage{}.Extract(context.Background(), carrier)
+			m := FromContext(out)
+
+			v, ok := m.Value(key.New("user.id"))
+			if !ok {
+				t.Fatalf("round-tripped Map is missing %q", "user.id")
+			}
+			if v.String != "abc-123" {
+				t.Errorf("round-tripped value = %q, want %q", v.String, "abc-123")
+			}
+
+			var gotHops int
+			var found bool
+			m.Foreach(func(e Entry) bool {
+				if e.Key.Name() == "user.id" {
+					gotHops = e.MaxHops
+					found = true
+				}
+				return true
+			})
+			if !found {
+				t.Fatalf("round-tripped Map has no entry for %q", "user.id")
+			}
+			wantHops := tc.maxHops
+			if wantHops > 0 {
+				wantHops--
+			}
+			if gotHops != wantHops {
+				t.Errorf("round-tripped MaxHops = %d, want %d", gotHops, wantHops)
+			}
+		})
+	}
+}
+
+// TestBaggageExtractDropsExhaustedEntries checks that an entry with no
+// remaining hop budget does not survive Extract.
+func TestBaggageExtractDropsExhaustedEntries(t *testing.T) {
+	carrier := mapCarrier{"baggage": "user.id=abc-123;ttl=-1;hops=0"}
+
+	out := Baggage{}.Extract(context.Background(), carrier)
+	m := FromContext(out)
+
+	if m.Len() != 0 {
+		t.Errorf("Extract kept %d entries, want 0 for a zero-hop entry", m.Len())
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package key provides the concrete core.Key implementation used to
+// build attributes and labels throughout the API.
+package key
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/unit"
+)
+
+// key is the concrete core.Key implementation returned by New. It
+// carries no value of its own; Value/Bool/Int/etc. pair it with one to
+// produce a core.KeyValue.
+type key struct {
+	name string
+}
+
+var _ core.Key = key{}
+
+// New returns a Key identified by name, e.g. "http.method".
+func New(name string) core.Key {
+	return key{name: name}
+}
+
+func (k key) Name() string               { return k.name }
+func (k key) Description() string        { return "" }
+func (k key) Unit() unit.Unit            { return unit.Dimensionless }
+func (k key) DefinitionID() core.EventID { return 0 }
+
+// Value returns a core.KeyValue pairing k with the zero Value. It
+// exists to satisfy core.Key for keys that are only ever used to read
+// attributes back out, not to construct them.
+func (k key) Value(ctx context.Context) core.KeyValue {
+	return core.KeyValue{Key: k, Value: core.Value{Type: core.INVALID}}
+}
+
+func (k key) Bool(v bool) core.KeyValue {
+	return core.KeyValue{Key: k, Value: core.Value{Type: core.BOOL, Bool: v}}
+}
+
+func (k key) Int(v int) core.KeyValue {
+	return k.Int64(int64(v))
+}
+
+func (k key) Int32(v int32) core.KeyValue {
+	return k.Int64(int64(v))
+}
+
+func (k key) Int64(v int64) core.KeyValue {
+	return core.KeyValue{Key: k, Value: core.Value{Type: core.INT64, Int64: v}}
+}
+
+func (k key) Uint(v uint) core.KeyValue {
+	return k.Uint64(uint64(v))
+}
+
+func (k key) Uint32(v uint32) core.KeyValue {
+	return k.Uint64(uint64(v))
+}
+
+func (k key) Uint64(v uint64) core.KeyValue {
+	return core.KeyValue{Key: k, Value: core.Value{Type: core.UINT64, Uint64: v}}
+}
+
+func (k key) Float32(v float32) core.KeyValue {
+	return k.Float64(float64(v))
+}
+
+func (k key) Float64(v float64) core.KeyValue {
+	return core.KeyValue{Key: k, Value: core.Value{Type: core.FLOAT64, Float64: v}}
+}
+
+func (k key) String(v string) core.KeyValue {
+	return core.KeyValue{Key: k, Value: core.Value{Type: core.STRING, String: v}}
+}
+
+func (k key) Bytes(v []byte) core.KeyValue {
+	return core.KeyValue{Key: k, Value: core.Value{Type: core.BYTES, Bytes: v}}
+}
+
+// lazyKey is a Key whose Value is computed from ctx on demand rather
+// than fixed at construction. It reuses key for every eager setter
+// (Bool, Int, ...); only Value is lazy.
+type lazyKey struct {
+	key
+	fn func(context.Context) core.Value
+}
+
+var _ core.Key = lazyKey{}
+
+// LazyKey returns a Key whose Value(ctx) defers to fn, memoizing the
+// result the first time the resulting Value is Emit'd. Use this for
+// attributes that are expensive to compute but only occasionally read,
+// e.g. derived from the current span or a config lookup.
+func LazyKey(name string, fn func(context.Context) core.Value) core.Key {
+	return lazyKey{key: key{name: name}, fn: fn}
+}
+
+func (k lazyKey) Value(ctx context.Context) core.KeyValue {
+	fn := k.fn
+	return core.KeyValue{
+		Key:   k,
+		Value: core.LazyValue(func() core.Value { return fn(ctx) }),
+	}
+}
+
+// internedKey is a Key whose String setter interns its argument via
+// core.InternedString. It reuses key for every other setter; only
+// String is overridden.
+type internedKey struct {
+	key
+}
+
+var _ core.Key = internedKey{}
+
+// Interned returns a Key identified by name whose String method
+// deduplicates repeated values against a bounded LRU cache (see
+// core.InternedString), so equal values share one backing array
+// instead of each call allocating its own copy. Use this only when
+// the same value is expected to recur across calls, e.g. a status
+// string drawn from a small fixed set; use New and its plain String
+// method otherwise.
+//
+// This deliberately does not help a per-request URL path or other
+// genuinely high-cardinality value: almost every call sees a distinct
+// string there, so the cache never gets a hit and every call still
+// pays for the lock. Deduplicating those would need bounding by
+// something other than exact string equality (a prefix/template match,
+// for instance), which core.InternedString does not attempt; dedup
+// that case by normalizing the value (e.g. to a route template) before
+// calling String, not by reaching for Interned.
+func Interned(name string) core.Key {
+	return internedKey{key: key{name: name}}
+}
+
+func (k internedKey) String(v string) core.KeyValue {
+	return core.KeyValue{Key: k, Value: core.Value{Type: core.STRING, String: core.InternedString(v)}}
+}
@@ -0,0 +1,47 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package key
+
+import (
+	"fmt"
+	"testing"
+)
+
+// BenchmarkKeyValueSet records the same small set of repeated string
+// values through an Interned key, the shape Interned's String method is
+// meant to optimize: equal values should share one backing array
+// instead of each call allocating its own copy.
+func BenchmarkKeyValueSet(b *testing.B) {
+	k := Interned("rpc.grpc.status_code")
+	values := []string{"OK", "CANCELLED", "UNKNOWN", "NOT_FOUND"}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = k.String(values[i%len(values)])
+	}
+}
+
+// BenchmarkKeyValueSetHighCardinality records distinct, never-repeating
+// string values through a plain key, the shape callers with
+// high-cardinality attributes (e.g. per-request URL paths) should use:
+// no caching overhead, since nothing would be deduplicated anyway.
+func BenchmarkKeyValueSetHighCardinality(b *testing.B) {
+	k := New("http.target")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = k.String(fmt.Sprintf("/v1/users/%d", i))
+	}
+}
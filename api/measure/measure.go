@@ -0,0 +1,54 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package measure provides a minimal core.Measure implementation for
+// instrumentation packages that define their own measures but have no
+// aggregation behavior of their own, e.g. plugin/grpc and
+// exporters/otlp reporting on their own operation.
+package measure
+
+import (
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/key"
+	"github.com/open-telemetry/opentelemetry-go/api/unit"
+)
+
+// measure is the concrete core.Measure implementation returned by New.
+type measure struct {
+	name string
+	desc string
+	unit unit.Unit
+}
+
+var _ core.Measure = measure{}
+
+// New returns a Measure identified by name. Whatever receives the
+// Measurements it produces (e.g. a plugin/grpc Recorder) is left to
+// aggregate them; New itself does not aggregate them.
+func New(name, desc string, u unit.Unit) core.Measure {
+	return measure{name: name, desc: desc, unit: u}
+}
+
+func (m measure) Name() string               { return m.name }
+func (m measure) Description() string        { return m.desc }
+func (m measure) Unit() unit.Unit            { return m.unit }
+func (m measure) DefinitionID() core.EventID { return 0 }
+
+func (m measure) M(v float64) core.Measurement {
+	return core.Measurement{Measure: m, Value: v}
+}
+
+func (m measure) V(v float64) core.KeyValue {
+	return key.New(m.name).Float64(v)
+}
@@ -0,0 +1,60 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exemplar retains a sample of the Measurements behind a
+// metric aggregation, so a viewer can jump from a bucket or data point
+// to a trace that produced it.
+package exemplar
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/propagation"
+)
+
+// Exemplar is a single retained Measurement, correlated with the trace
+// that was active when it was recorded.
+type Exemplar struct {
+	Value       float64
+	Timestamp   time.Time
+	SpanContext core.SpanContext
+	Attributes  []core.KeyValue
+}
+
+// Reservoir collects a bounded set of Exemplars out of a stream of
+// Measurements offered to it by an Aggregator.
+type Reservoir interface {
+	// Offer considers measurement for retention as of now. Measurements
+	// whose SpanContext is not sampled are never retained, to keep
+	// reservoir churn bounded to the cost of sampled traffic.
+	Offer(ctx context.Context, measurement core.Measurement, now time.Time)
+
+	// Collect returns the Exemplars currently retained.
+	Collect() []Exemplar
+}
+
+// spanContext resolves the SpanContext to attribute measurement to:
+// the one already attached to its ScopeID, or else whatever is active
+// in ctx.
+func spanContext(ctx context.Context, measurement core.Measurement) core.SpanContext {
+	if sc := measurement.ScopeID.SpanContext; sc.HasTraceID() || sc.HasSpanID() {
+		return sc
+	}
+	if sc, ok := propagation.SpanContextFromContext(ctx); ok {
+		return sc
+	}
+	return core.INVALID_SPAN_CONTEXT
+}
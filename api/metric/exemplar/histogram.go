@@ -0,0 +1,73 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+// AlignedHistogramReservoir keeps the most recent Exemplar observed in
+// each bucket of a histogram, aligned to the same bounds the histogram
+// aggregation itself uses. Unlike UniformReservoir it always retains
+// exactly one sample per bucket that has been offered any sampled
+// Measurement, rather than a fixed total count.
+type AlignedHistogramReservoir struct {
+	mu      sync.Mutex
+	bounds  []float64 // ascending bucket upper bounds; the last bucket is (bounds[len-1], +Inf)
+	samples []*Exemplar
+}
+
+var _ Reservoir = (*AlignedHistogramReservoir)(nil)
+
+// NewAlignedHistogramReservoir returns a reservoir with one bucket per
+// boundary in bounds, plus an overflow bucket above the last boundary.
+func NewAlignedHistogramReservoir(bounds []float64) *AlignedHistogramReservoir {
+	return &AlignedHistogramReservoir{
+		bounds:  bounds,
+		samples: make([]*Exemplar, len(bounds)+1),
+	}
+}
+
+func (r *AlignedHistogramReservoir) Offer(ctx context.Context, measurement core.Measurement, now time.Time) {
+	sc := spanContext(ctx, measurement)
+	if !sc.IsSampled() {
+		return
+	}
+	ex := Exemplar{Value: measurement.Value, Timestamp: now, SpanContext: sc}
+
+	idx := sort.SearchFloat64s(r.bounds, measurement.Value)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples[idx] = &ex
+}
+
+func (r *AlignedHistogramReservoir) Collect() []Exemplar {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Exemplar, 0, len(r.samples))
+	for _, s := range r.samples {
+		if s != nil {
+			out = append(out, *s)
+		}
+	}
+	return out
+}
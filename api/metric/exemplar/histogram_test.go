@@ -0,0 +1,74 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestAlignedHistogramReservoirBucketAlignment checks that Offer
+// places each Measurement into the same bucket sort.SearchFloat64s
+// would assign it to, including the upper (+Inf) overflow bucket, and
+// that Collect returns them in ascending bucket order.
+func TestAlignedHistogramReservoirBucketAlignment(t *testing.T) {
+	r := NewAlignedHistogramReservoir([]float64{1, 5, 10})
+
+	values := []float64{0.5, 5, 7, 20}
+	for _, v := range values {
+		r.Offer(context.Background(), sampledMeasurement(v), time.Now())
+	}
+
+	samples := r.Collect()
+	if got, want := len(samples), len(values); got != want {
+		t.Fatalf("len(Collect()) = %d, want %d", got, want)
+	}
+	for i, ex := range samples {
+		if ex.Value != values[i] {
+			t.Errorf("Collect()[%d].Value = %v, want %v", i, ex.Value, values[i])
+		}
+	}
+}
+
+// TestAlignedHistogramReservoirOverwritesSameBucket checks that a
+// second Measurement landing in an already-occupied bucket replaces
+// the first, keeping only the most recent sample per bucket.
+func TestAlignedHistogramReservoirOverwritesSameBucket(t *testing.T) {
+	r := NewAlignedHistogramReservoir([]float64{1, 5, 10})
+
+	r.Offer(context.Background(), sampledMeasurement(2), time.Now())
+	r.Offer(context.Background(), sampledMeasurement(3), time.Now())
+
+	samples := r.Collect()
+	if got, want := len(samples), 1; got != want {
+		t.Fatalf("len(Collect()) = %d, want %d", got, want)
+	}
+	if samples[0].Value != 3 {
+		t.Errorf("Collect()[0].Value = %v, want %v (the most recent offer)", samples[0].Value, 3.0)
+	}
+}
+
+// TestAlignedHistogramReservoirIgnoresUnsampled checks that Offer
+// never retains a Measurement whose SpanContext is not sampled.
+func TestAlignedHistogramReservoirIgnoresUnsampled(t *testing.T) {
+	r := NewAlignedHistogramReservoir([]float64{1, 5, 10})
+
+	r.Offer(context.Background(), unsampledMeasurement(2), time.Now())
+
+	if got := len(r.Collect()); got != 0 {
+		t.Fatalf("len(Collect()) = %d, want 0 for unsampled offers", got)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+// UniformReservoir retains a fixed-size uniform random sample of the
+// offered Measurements, using reservoir sampling (Algorithm R): every
+// Measurement seen so far is equally likely to be among the retained
+// ones, regardless of how many have been offered in total.
+type UniformReservoir struct {
+	mu      sync.Mutex
+	size    int
+	count   int64
+	samples []Exemplar
+	rng     *rand.Rand
+}
+
+var _ Reservoir = (*UniformReservoir)(nil)
+
+// NewUniformReservoir returns a UniformReservoir retaining at most size
+// Exemplars.
+func NewUniformReservoir(size int) *UniformReservoir {
+	return &UniformReservoir{
+		size: size,
+		rng:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (r *UniformReservoir) Offer(ctx context.Context, measurement core.Measurement, now time.Time) {
+	sc := spanContext(ctx, measurement)
+	if !sc.IsSampled() {
+		return
+	}
+	ex := Exemplar{Value: measurement.Value, Timestamp: now, SpanContext: sc}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.count++
+	if len(r.samples) < r.size {
+		r.samples = append(r.samples, ex)
+		return
+	}
+	if j := r.rng.Int63n(r.count); j < int64(r.size) {
+		r.samples[j] = ex
+	}
+}
+
+func (r *UniformReservoir) Collect() []Exemplar {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Exemplar, len(r.samples))
+	copy(out, r.samples)
+	return out
+}
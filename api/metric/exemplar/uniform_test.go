@@ -0,0 +1,109 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+func sampledMeasurement(value float64) core.Measurement {
+	sc := core.SpanContext{TraceID: core.TraceID{1}, SpanID: core.SpanID{1}, TraceFlags: core.FlagsSampled}
+	return core.Measurement{Value: value, ScopeID: core.ScopeID{SpanContext: sc}}
+}
+
+func unsampledMeasurement(value float64) core.Measurement {
+	sc := core.SpanContext{TraceID: core.TraceID{1}, SpanID: core.SpanID{1}}
+	return core.Measurement{Value: value, ScopeID: core.ScopeID{SpanContext: sc}}
+}
+
+// TestUniformReservoirRetainsAllWithinCapacity checks that offering no
+// more Measurements than the reservoir's size retains every one of
+// them.
+func TestUniformReservoirRetainsAllWithinCapacity(t *testing.T) {
+	r := NewUniformReservoir(3)
+	for i := 0; i < 3; i++ {
+		r.Offer(context.Background(), sampledMeasurement(float64(i)), time.Now())
+	}
+
+	samples := r.Collect()
+	if got, want := len(samples), 3; got != want {
+		t.Fatalf("len(Collect()) = %d, want %d", got, want)
+	}
+}
+
+// TestUniformReservoirCapsAtSize checks that Collect never returns
+// more Exemplars than the reservoir's configured size, regardless of
+// how many Measurements were offered.
+func TestUniformReservoirCapsAtSize(t *testing.T) {
+	r := NewUniformReservoir(5)
+	for i := 0; i < 100; i++ {
+		r.Offer(context.Background(), sampledMeasurement(float64(i)), time.Now())
+	}
+
+	if got, want := len(r.Collect()), 5; got != want {
+		t.Fatalf("len(Collect()) = %d, want %d", got, want)
+	}
+}
+
+// TestUniformReservoirIgnoresUnsampled checks that Offer never retains
+// a Measurement whose SpanContext is not sampled.
+func TestUniformReservoirIgnoresUnsampled(t *testing.T) {
+	r := NewUniformReservoir(10)
+	for i := 0; i < 5; i++ {
+		r.Offer(context.Background(), unsampledMeasurement(float64(i)), time.Now())
+	}
+
+	if got := len(r.Collect()); got != 0 {
+		t.Fatalf("len(Collect()) = %d, want 0 for unsampled offers", got)
+	}
+}
+
+// TestUniformReservoirAlgorithmRDistribution checks that Algorithm R's
+// replacement probability keeps every offered Measurement's retention
+// odds equal, by offering a fixed population many independent times
+// and confirming each one's empirical retention frequency converges to
+// size/population.
+func TestUniformReservoirAlgorithmRDistribution(t *testing.T) {
+	const (
+		population = 5
+		size       = 2
+		trials     = 20000
+	)
+	want := float64(size) / float64(population)
+
+	retained := make([]int, population)
+	for trial := 0; trial < trials; trial++ {
+		r := NewUniformReservoir(size)
+		for i := 0; i < population; i++ {
+			r.Offer(context.Background(), sampledMeasurement(float64(i)), time.Now())
+		}
+		for _, ex := range r.Collect() {
+			retained[int(ex.Value)]++
+		}
+	}
+
+	const tolerance = 0.05
+	for i, count := range retained {
+		got := float64(count) / float64(trials)
+		if math.Abs(got-want) > tolerance {
+			t.Errorf("item %d retained with frequency %.4f, want ~%.4f (+/- %.2f)", i, got, want, tolerance)
+		}
+	}
+}
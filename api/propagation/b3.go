@@ -0,0 +1,158 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+var errInvalidB3ID = errors.New("b3: invalid id")
+
+const (
+	b3SingleHeader   = "b3"
+	b3TraceIDHeader  = "x-b3-traceid"
+	b3SpanIDHeader   = "x-b3-spanid"
+	b3SampledHeader  = "x-b3-sampled"
+	b3FlagsHeader    = "x-b3-flags"
+	b3DebugFlagValue = "1"
+)
+
+// B3Encoding selects which wire form B3.Inject writes.
+type B3Encoding int
+
+const (
+	// B3SingleHeader writes the single "b3" header.
+	B3SingleHeader B3Encoding = iota
+	// B3MultiHeader writes the "x-b3-*" header family.
+	B3MultiHeader
+)
+
+// B3 implements Zipkin's B3 propagation format for interop with
+// B3-instrumented services. Extract always recognizes both the single
+// and multi-header forms regardless of the configured Encoding.
+type B3 struct {
+	Encoding B3Encoding
+}
+
+var _ TextMapPropagator = B3{}
+
+// Inject writes the SpanContext found in ctx into carrier using the
+// configured B3 encoding. It is a no-op if ctx carries no SpanContext.
+func (b3 B3) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok || !sc.HasTraceID() || !sc.HasSpanID() {
+		return
+	}
+
+	sampled := "0"
+	if sc.IsSampled() {
+		sampled = "1"
+	}
+
+	switch b3.Encoding {
+	case B3MultiHeader:
+		carrier.Set(b3TraceIDHeader, sc.TraceID.String())
+		carrier.Set(b3SpanIDHeader, sc.SpanID.String())
+		carrier.Set(b3SampledHeader, sampled)
+	default:
+		carrier.Set(b3SingleHeader, sc.TraceID.String()+"-"+sc.SpanID.String()+"-"+sampled)
+	}
+}
+
+// Extract reads a SpanContext from either the single "b3" header or the
+// "x-b3-*" header family, whichever is present, and returns a context
+// carrying it. If neither form is present or well-formed, ctx is
+// returned unchanged.
+func (b3 B3) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	if single := carrier.Get(b3SingleHeader); single != "" {
+		if sc, ok := parseB3Single(single); ok {
+			return ContextWithSpanContext(ctx, sc)
+		}
+		return ctx
+	}
+
+	traceID, err := parseB3TraceID(carrier.Get(b3TraceIDHeader))
+	if err != nil {
+		return ctx
+	}
+	spanID, err := parseB3SpanID(carrier.Get(b3SpanIDHeader))
+	if err != nil {
+		return ctx
+	}
+
+	sc := core.SpanContext{TraceID: traceID, SpanID: spanID}
+	if carrier.Get(b3SampledHeader) == "1" || carrier.Get(b3FlagsHeader) == b3DebugFlagValue {
+		sc.TraceFlags = core.FlagsSampled
+	}
+	return ContextWithSpanContext(ctx, sc)
+}
+
+// Fields returns the carrier keys used by B3, across both encodings,
+// since Extract accepts either form regardless of Encoding.
+func (b3 B3) Fields() []string {
+	return []string{b3SingleHeader, b3TraceIDHeader, b3SpanIDHeader, b3SampledHeader, b3FlagsHeader}
+}
+
+func parseB3Single(s string) (core.SpanContext, bool) {
+	parts := strings.Split(s, "-")
+	if len(parts) < 2 {
+		return core.SpanContext{}, false
+	}
+
+	traceID, err := parseB3TraceID(parts[0])
+	if err != nil {
+		return core.SpanContext{}, false
+	}
+	spanID, err := parseB3SpanID(parts[1])
+	if err != nil {
+		return core.SpanContext{}, false
+	}
+
+	sc := core.SpanContext{TraceID: traceID, SpanID: spanID}
+	if len(parts) >= 3 && (parts[2] == "1" || parts[2] == b3DebugFlagValue) {
+		sc.TraceFlags = core.FlagsSampled
+	}
+	return sc, true
+}
+
+// parseB3TraceID accepts both the 128-bit and legacy 64-bit (zero
+// left-padded) forms of the B3 trace ID.
+func parseB3TraceID(s string) (core.TraceID, error) {
+	var traceID core.TraceID
+	if len(s) == 16 {
+		s = strings.Repeat("0", 16) + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(traceID) {
+		return core.TraceID{}, errInvalidB3ID
+	}
+	copy(traceID[:], b)
+	return traceID, nil
+}
+
+func parseB3SpanID(s string) (core.SpanID, error) {
+	var spanID core.SpanID
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(spanID) {
+		return core.SpanID{}, errInvalidB3ID
+	}
+	copy(spanID[:], b)
+	return spanID, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+// TestB3InjectExtractRoundTrip checks that a SpanContext survives an
+// Inject/Extract round trip in both the single-header and
+// multi-header encodings.
+func TestB3InjectExtractRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		encoding B3Encoding
+	}{
+		{"single header", B3SingleHeader},
+		{"multi header", B3MultiHeader},
+	}
+
+	sc := testSpanContext()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b3 := B3{Encoding: tc.encoding}
+			ctx := ContextWithSpanContext(context.Background(), sc)
+			carrier := mapCarrier{}
+			b3.Inject(ctx, carrier)
+
+			out := b3.Extract(context.Background(), carrier)
+			got, ok := SpanContextFromContext(out)
+			if !ok {
+				t.Fatal("Extract did not produce a SpanContext")
+			}
+			if got.TraceID != sc.TraceID || got.SpanID != sc.SpanID {
+				t.Errorf("round-tripped SpanContext = %+v, want %+v", got, sc)
+			}
+			if !got.IsSampled() {
+				t.Error("round-tripped SpanContext lost the sampled flag")
+			}
+		})
+	}
+}
+
+// TestB3ExtractAcceptsEitherEncoding checks that Extract recognizes
+// both wire forms regardless of the configured Encoding, per the
+// documented behavior.
+func TestB3ExtractAcceptsEitherEncoding(t *testing.T) {
+	sc := testSpanContext()
+
+	single := B3{Encoding: B3SingleHeader}
+	carrier := mapCarrier{}
+	single.Inject(ContextWithSpanContext(context.Background(), sc), carrier)
+
+	multi := B3{Encoding: B3MultiHeader}
+	out := multi.Extract(context.Background(), carrier)
+	got, ok := SpanContextFromContext(out)
+	if !ok || got.TraceID != sc.TraceID || got.SpanID != sc.SpanID {
+		t.Errorf("multi-encoded Extract of a single-encoded carrier = %+v, %v; want %+v, true", got, ok, sc)
+	}
+}
+
+// TestB3ExtractLegacy64BitTraceID checks that Extract accepts the
+// legacy 64-bit (zero left-padded) B3 trace ID form.
+func TestB3ExtractLegacy64BitTraceID(t *testing.T) {
+	carrier := mapCarrier{
+		b3TraceIDHeader: "a3ce929d0e0e4736",
+		b3SpanIDHeader:  "00f067aa0ba902b7",
+		b3SampledHeader: "1",
+	}
+
+	out := B3{}.Extract(context.Background(), carrier)
+	got, ok := SpanContextFromContext(out)
+	if !ok {
+		t.Fatal("Extract did not produce a SpanContext")
+	}
+	want := core.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	if got.TraceID != want {
+		t.Errorf("TraceID = %x, want %x", got.TraceID, want)
+	}
+	if !got.IsSampled() {
+		t.Error("sampled flag not set from x-b3-sampled: 1")
+	}
+}
+
+// TestB3ExtractMalformed checks that Extract leaves ctx unchanged when
+// neither B3 form is present or well-formed.
+func TestB3ExtractMalformed(t *testing.T) {
+	cases := []struct {
+		name    string
+		carrier mapCarrier
+	}{
+		{"empty", mapCarrier{}},
+		{"malformed single header", mapCarrier{b3SingleHeader: "not-hex"}},
+		{"malformed multi header trace id", mapCarrier{b3TraceIDHeader: "zz", b3SpanIDHeader: "00f067aa0ba902b7"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := B3{}.Extract(context.Background(), tc.carrier)
+			if _, ok := SpanContextFromContext(out); ok {
+				t.Error("Extract produced a SpanContext from malformed input")
+			}
+		})
+	}
+}
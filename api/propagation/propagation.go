@@ -0,0 +1,65 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package propagation carries a core.SpanContext across process
+// boundaries on behalf of exporters and instrumentation plugins.
+package propagation
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+type (
+	// TextMapCarrier is the storage medium used by a TextMapPropagator,
+	// e.g. HTTP headers or gRPC metadata.
+	TextMapCarrier interface {
+		Get(key string) string
+		Set(key, value string)
+	}
+
+	// TextMapPropagator injects and extracts a core.SpanContext (and any
+	// other values it is configured to carry) using string key/value
+	// pairs on a TextMapCarrier.
+	TextMapPropagator interface {
+		// Inject exports values from ctx into carrier.
+		Inject(ctx context.Context, carrier TextMapCarrier)
+
+		// Extract reads values from carrier into a new context derived
+		// from ctx.
+		Extract(ctx context.Context, carrier TextMapCarrier) context.Context
+
+		// Fields returns the carrier keys this propagator reads/writes,
+		// so HTTP servers can declare them in a Vary header.
+		Fields() []string
+	}
+)
+
+type spanContextKeyType struct{}
+
+var spanContextKey spanContextKeyType
+
+// ContextWithSpanContext returns a copy of ctx carrying sc, to be picked
+// up by a TextMapPropagator's Inject.
+func ContextWithSpanContext(ctx context.Context, sc core.SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey, sc)
+}
+
+// SpanContextFromContext returns the core.SpanContext previously stored
+// in ctx by ContextWithSpanContext or a TextMapPropagator's Extract.
+func SpanContextFromContext(ctx context.Context) (core.SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey).(core.SpanContext)
+	return sc, ok
+}
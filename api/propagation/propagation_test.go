@@ -0,0 +1,113 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+// mapCarrier is a minimal TextMapCarrier backed by a map, for use in
+// tests that don't need real HTTP headers or gRPC metadata.
+type mapCarrier map[string]string
+
+func (c mapCarrier) Get(k string) string { return c[k] }
+func (c mapCarrier) Set(k, v string)     { c[k] = v }
+
+func testSpanContext() core.SpanContext {
+	return core.SpanContext{
+		TraceID:    core.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     core.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: core.FlagsSampled,
+	}
+}
+
+// TestTraceContextInjectExtractRoundTrip checks that a SpanContext,
+// including its TraceState, survives an Inject/Extract round trip.
+func TestTraceContextInjectExtractRoundTrip(t *testing.T) {
+	sc := testSpanContext()
+	sc.TraceState = core.NewTraceState(core.TraceStateEntry{Key: "vendor1", Value: "value1"})
+
+	ctx := ContextWithSpanContext(context.Background(), sc)
+	carrier := mapCarrier{}
+	TraceContext{}.Inject(ctx, carrier)
+
+	out := TraceContext{}.Extract(context.Background(), carrier)
+	got, ok := SpanContextFromContext(out)
+	if !ok {
+		t.Fatal("Extract did not produce a SpanContext")
+	}
+	if got.TraceID != sc.TraceID || got.SpanID != sc.SpanID || got.TraceFlags != sc.TraceFlags {
+		t.Errorf("round-tripped SpanContext = %+v, want %+v", got, sc)
+	}
+	if got.TraceState.String() != sc.TraceState.String() {
+		t.Errorf("round-tripped TraceState = %q, want %q", got.TraceState.String(), sc.TraceState.String())
+	}
+}
+
+// TestTraceContextInjectNoSpanContext checks that Inject is a no-op
+// when ctx carries no SpanContext.
+func TestTraceContextInjectNoSpanContext(t *testing.T) {
+	carrier := mapCarrier{}
+	TraceContext{}.Inject(context.Background(), carrier)
+
+	if len(carrier) != 0 {
+		t.Errorf("Inject wrote %d carrier keys for an empty context, want 0", len(carrier))
+	}
+}
+
+// TestTraceContextExtractMalformed checks that Extract leaves ctx
+// unchanged when the "traceparent" header is missing or malformed.
+func TestTraceContextExtractMalformed(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+	}{
+		{"missing", ""},
+		{"wrong field count", "00-bad"},
+		{"unsupported version", "99-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			carrier := mapCarrier{}
+			if tc.header != "" {
+				carrier.Set(traceParentHeader, tc.header)
+			}
+			ctx := context.Background()
+			out := TraceContext{}.Extract(ctx, carrier)
+			if _, ok := SpanContextFromContext(out); ok {
+				t.Error("Extract produced a SpanContext from a malformed traceparent")
+			}
+		})
+	}
+}
+
+// TestTraceContextFields checks that Fields advertises both headers
+// TraceContext reads and writes.
+func TestTraceContextFields(t *testing.T) {
+	fields := TraceContext{}.Fields()
+	want := map[string]bool{traceParentHeader: true, traceStateHeader: true}
+	if len(fields) != len(want) {
+		t.Fatalf("Fields() = %v, want keys %v", fields, want)
+	}
+	for _, f := range fields {
+		if !want[f] {
+			t.Errorf("Fields() contains unexpected key %q", f)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+const (
+	traceParentHeader = "traceparent"
+	traceStateHeader  = "tracestate"
+)
+
+// TraceContext implements the W3C Trace Context propagation format,
+// carrying a core.SpanContext via the "traceparent" and "tracestate"
+// headers.
+type TraceContext struct{}
+
+var _ TextMapPropagator = TraceContext{}
+
+// Inject writes the SpanContext found in ctx into carrier. It is a
+// no-op if ctx carries no SpanContext.
+func (TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok || !sc.HasTraceID() || !sc.HasSpanID() {
+		return
+	}
+
+	carrier.Set(traceParentHeader, sc.TraceParent())
+	if state := sc.TraceState.String(); state != "" {
+		carrier.Set(traceStateHeader, state)
+	}
+}
+
+// Extract reads a SpanContext from carrier's "traceparent"/"tracestate"
+// headers and returns a context carrying it. If "traceparent" is
+// missing or malformed, ctx is returned unchanged.
+func (TraceContext) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	sc, err := core.FromTraceParent(carrier.Get(traceParentHeader))
+	if err != nil {
+		return ctx
+	}
+
+	if state, err := core.ParseTraceState(carrier.Get(traceStateHeader)); err == nil {
+		sc.TraceState = state
+	}
+
+	return ContextWithSpanContext(ctx, sc)
+}
+
+// Fields returns the carrier keys used by TraceContext.
+func (TraceContext) Fields() []string {
+	return []string{traceParentHeader, traceStateHeader}
+}
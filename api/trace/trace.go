@@ -0,0 +1,184 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trace provides the minimal Tracer/Span surface that
+// instrumentation plugins and exporters build on.
+package trace
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+type (
+	// Span is a single operation within a trace.
+	Span interface {
+		// End completes the Span. No further calls, other than to End
+		// itself, are valid after it returns.
+		End(options ...EndOption)
+
+		// Tracer returns the Tracer that created this Span.
+		Tracer() Tracer
+
+		AddEvent(ctx context.Context, name string, attrs ...core.KeyValue)
+		SetStatus(code codes.Code)
+		SetName(name string)
+		SetAttributes(attrs ...core.KeyValue)
+
+		SpanContext() core.SpanContext
+		IsRecording() bool
+	}
+
+	// Tracer starts new Spans.
+	Tracer interface {
+		// Start creates a new Span as a child of the Span (if any)
+		// found in ctx, and returns a context carrying the new Span
+		// alongside the Span itself.
+		Start(ctx context.Context, spanName string, opts ...StartOption) (context.Context, Span)
+	}
+
+	// TracerProvider supplies named Tracers to instrumentation.
+	TracerProvider interface {
+		// Tracer returns a Tracer identified by instrumentationName
+		// (typically the instrumenting package's import path).
+		Tracer(instrumentationName string, opts ...TracerOption) Tracer
+	}
+
+	// SpanKind describes a Span's relationship to its parent and
+	// children, e.g. whether it represents an RPC server or client.
+	SpanKind int
+
+	// Link associates a Span with another SpanContext, e.g. a batch
+	// consumer linking back to each message producer's span.
+	Link struct {
+		SpanContext core.SpanContext
+		Attributes  []core.KeyValue
+	}
+
+	// StartConfig is built up by StartOptions and passed to a Tracer's
+	// Start implementation.
+	StartConfig struct {
+		Attributes []core.KeyValue
+		StartTime  time.Time
+		Links      []Link
+		NewRoot    bool
+		SpanKind   SpanKind
+	}
+	StartOption func(*StartConfig)
+
+	// EndConfig is built up by EndOptions and passed to a Span's End
+	// implementation.
+	EndConfig struct {
+		EndTime time.Time
+	}
+	EndOption func(*EndConfig)
+
+	// TracerConfig is built up by TracerOptions and passed to a
+	// TracerProvider's Tracer implementation.
+	TracerConfig struct {
+		InstrumentationVersion string
+	}
+	TracerOption func(*TracerConfig)
+)
+
+const (
+	SpanKindUnspecified SpanKind = iota
+	SpanKindInternal
+	SpanKindServer
+	SpanKindClient
+	SpanKindProducer
+	SpanKindConsumer
+)
+
+// WithSpanKind sets the SpanKind of the new Span.
+func WithSpanKind(kind SpanKind) StartOption {
+	return func(c *StartConfig) { c.SpanKind = kind }
+}
+
+// WithAttributes adds attributes to the new Span.
+func WithAttributes(attrs ...core.KeyValue) StartOption {
+	return func(c *StartConfig) { c.Attributes = append(c.Attributes, attrs...) }
+}
+
+// WithStartTime sets an explicit start time instead of the call time.
+func WithStartTime(t time.Time) StartOption {
+	return func(c *StartConfig) { c.StartTime = t }
+}
+
+// WithEndTime sets an explicit end time instead of the call time.
+func WithEndTime(t time.Time) EndOption {
+	return func(c *EndConfig) { c.EndTime = t }
+}
+
+// WithInstrumentationVersion sets the instrumenting library's version.
+func WithInstrumentationVersion(version string) TracerOption {
+	return func(c *TracerConfig) { c.InstrumentationVersion = version }
+}
+
+type spanContextKeyType struct{}
+
+var currentSpanKey spanContextKeyType
+
+// ContextWithSpan returns a copy of ctx carrying span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, currentSpanKey, span)
+}
+
+// SpanFromContext returns the Span previously stored in ctx by
+// ContextWithSpan, or noopSpan{} if ctx carries none.
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(currentSpanKey).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) End(...EndOption) {}
+
+func (s noopSpan) Tracer() Tracer { return noopTracer{} }
+
+func (noopSpan) AddEvent(context.Context, string, ...core.KeyValue) {}
+
+func (noopSpan) SetStatus(codes.Code) {}
+
+func (noopSpan) SetName(string) {}
+
+func (noopSpan) SetAttributes(...core.KeyValue) {}
+
+func (noopSpan) SpanContext() core.SpanContext { return core.INVALID_SPAN_CONTEXT }
+
+func (noopSpan) IsRecording() bool { return false }
+
+type noopTracer struct{}
+
+func (t noopTracer) Start(ctx context.Context, _ string, _ ...StartOption) (context.Context, Span) {
+	span := noopSpan{}
+	return ContextWithSpan(ctx, span), span
+}
+
+// NoopTracerProvider returns a TracerProvider whose Tracers produce
+// Spans that record nothing, for use as a safe default.
+func NoopTracerProvider() TracerProvider {
+	return noopTracerProvider{}
+}
+
+type noopTracerProvider struct{}
+
+func (noopTracerProvider) Tracer(string, ...TracerOption) Tracer { return noopTracer{} }
@@ -0,0 +1,122 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"fmt"
+
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+)
+
+func withOutgoingHeader(ctx context.Context, key, value string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, key, value)
+}
+
+// grpcDriver speaks OTLP/gRPC to a collector.
+type grpcDriver struct {
+	cfg    config
+	conn   *grpc.ClientConn
+	traces coltracepb.TraceServiceClient
+	metric colmetricpb.MetricsServiceClient
+}
+
+func newGRPCDriver(cfg config) *grpcDriver {
+	return &grpcDriver{cfg: cfg}
+}
+
+func (d *grpcDriver) Start(ctx context.Context) error {
+	var dialOpts []grpc.DialOption
+	if d.cfg.tlsConfig != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(d.cfg.tlsConfig)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+	if d.cfg.compression == "gzip" {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	}
+
+	conn, err := grpc.DialContext(ctx, d.cfg.endpoint, dialOpts...)
+	if err != nil {
+		return fmt.Errorf("otlp: dial %s: %w", d.cfg.endpoint, err)
+	}
+
+	d.conn = conn
+	d.traces = coltracepb.NewTraceServiceClient(conn)
+	d.metric = colmetricpb.NewMetricsServiceClient(conn)
+	return nil
+}
+
+func (d *grpcDriver) Stop(ctx context.Context) error {
+	if d.conn == nil {
+		return nil
+	}
+	return d.conn.Close()
+}
+
+func (d *grpcDriver) ExportSpans(ctx context.Context, spans []ExportSpan) error {
+	req := &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			InstrumentationLibrarySpans: []*tracepb.InstrumentationLibrarySpans{{
+				Spans: toSpans(spans),
+			}},
+		}},
+	}
+	_, err := d.traces.Export(d.withHeaders(ctx), req)
+	return d.wrapRetryableError(err)
+}
+
+func (d *grpcDriver) ExportMeasurements(ctx context.Context, measurements []ExportMeasurement) error {
+	req := &colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{{
+			InstrumentationLibraryMetrics: []*metricpb.InstrumentationLibraryMetrics{{
+				Metrics: toMetrics(measurements),
+			}},
+		}},
+	}
+	_, err := d.metric.Export(d.withHeaders(ctx), req)
+	return d.wrapRetryableError(err)
+}
+
+func (d *grpcDriver) withHeaders(ctx context.Context) context.Context {
+	if len(d.cfg.headers) == 0 {
+		return ctx
+	}
+	for k, v := range d.cfg.headers {
+		ctx = withOutgoingHeader(ctx, k, v)
+	}
+	return ctx
+}
+
+// wrapRetryableError annotates the error with the status code's
+// OpenTelemetry span-status string, using the shared GrpcCodeToString
+// mapping, so the Processor's retry logic doesn't need its own copy of
+// the status.Code switch.
+func (d *grpcDriver) wrapRetryableError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("otlp: export failed (%s): %w", core.GrpcCodeToString(status.Code(err)), err)
+}
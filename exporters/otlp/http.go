@@ -0,0 +1,123 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+const (
+	tracesPath  = "/v1/traces"
+	metricsPath = "/v1/metrics"
+)
+
+// httpDriver speaks OTLP/HTTP (protobuf bodies) to a collector.
+type httpDriver struct {
+	cfg    config
+	client *http.Client
+}
+
+func newHTTPDriver(cfg config) *httpDriver {
+	return &httpDriver{cfg: cfg}
+}
+
+func (d *httpDriver) Start(ctx context.Context) error {
+	transport := &http.Transport{TLSClientConfig: d.cfg.tlsConfig}
+	d.client = &http.Client{Transport: transport}
+	return nil
+}
+
+func (d *httpDriver) Stop(ctx context.Context) error {
+	d.client.CloseIdleConnections()
+	return nil
+}
+
+func (d *httpDriver) ExportSpans(ctx context.Context, spans []ExportSpan) error {
+	req := &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			InstrumentationLibrarySpans: []*tracepb.InstrumentationLibrarySpans{{
+				Spans: toSpans(spans),
+			}},
+		}},
+	}
+	return d.post(ctx, tracesPath, req)
+}
+
+func (d *httpDriver) ExportMeasurements(ctx context.Context, measurements []ExportMeasurement) error {
+	req := &colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricpb.ResourceMetrics{{
+			InstrumentationLibraryMetrics: []*metricpb.InstrumentationLibraryMetrics{{
+				Metrics: toMetrics(measurements),
+			}},
+		}},
+	}
+	return d.post(ctx, metricsPath, req)
+}
+
+func (d *httpDriver) post(ctx context.Context, path string, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("otlp: marshal request: %w", err)
+	}
+
+	var reader io.Reader = bytes.NewReader(body)
+	contentEncoding := ""
+	if d.cfg.compression == "gzip" {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(body); err != nil {
+			return fmt.Errorf("otlp: gzip request: %w", err)
+		}
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("otlp: gzip request: %w", err)
+		}
+		reader = &buf
+		contentEncoding = "gzip"
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, d.cfg.endpoint+path, reader)
+	if err != nil {
+		return fmt.Errorf("otlp: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	if contentEncoding != "" {
+		httpReq.Header.Set("Content-Encoding", contentEncoding)
+	}
+	for k, v := range d.cfg.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := d.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("otlp: export failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp: export failed: status %s", resp.Status)
+	}
+	return nil
+}
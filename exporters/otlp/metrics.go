@@ -0,0 +1,24 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"github.com/open-telemetry/opentelemetry-go/api/measure"
+	"github.com/open-telemetry/opentelemetry-go/api/unit"
+)
+
+// exporterDropped counts batches dropped because the Processor's queue
+// was full, surfaced via Exporter.DroppedMeasurement.
+var exporterDropped = measure.New("otlp.exporter.dropped", "number of batches dropped by a full export queue", unit.Dimensionless)
@@ -0,0 +1,198 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlp exports spans and measurements to an OpenTelemetry
+// Collector (or any OTLP-speaking backend) over gRPC or HTTP.
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/metric/exemplar"
+)
+
+// Protocol selects the wire transport used to reach the collector.
+type Protocol int
+
+const (
+	// ProtocolGRPC sends OTLP requests over gRPC. This is the default.
+	ProtocolGRPC Protocol = iota
+	// ProtocolHTTP sends OTLP requests as HTTP/protobuf bodies.
+	ProtocolHTTP
+)
+
+// RetryConfig controls how the Exporter retries a failed export call.
+type RetryConfig struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// DefaultRetryConfig is used when WithRetryConfig is not supplied.
+var DefaultRetryConfig = RetryConfig{
+	Enabled:         true,
+	InitialInterval: 5 * time.Second,
+	MaxInterval:     30 * time.Second,
+	MaxElapsedTime:  time.Minute,
+}
+
+type config struct {
+	protocol    Protocol
+	endpoint    string
+	headers     map[string]string
+	tlsConfig   *tls.Config
+	compression string
+	retry       RetryConfig
+}
+
+func newConfig(opts ...Option) config {
+	cfg := config{
+		protocol: ProtocolGRPC,
+		endpoint: "localhost:55680",
+		retry:    DefaultRetryConfig,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Option configures the Exporter.
+type Option func(*config)
+
+// WithEndpoint sets the collector address, e.g. "collector:55680" for
+// gRPC or "https://collector:55681" for HTTP.
+func WithEndpoint(endpoint string) Option {
+	return func(cfg *config) { cfg.endpoint = endpoint }
+}
+
+// WithProtocol selects the wire transport. Defaults to ProtocolGRPC.
+func WithProtocol(protocol Protocol) Option {
+	return func(cfg *config) { cfg.protocol = protocol }
+}
+
+// WithHeaders attaches static headers/metadata to every export request,
+// e.g. for authentication.
+func WithHeaders(headers map[string]string) Option {
+	return func(cfg *config) { cfg.headers = headers }
+}
+
+// WithTLS enables transport security using tlsConfig. Without this
+// option the exporter connects insecurely.
+func WithTLS(tlsConfig *tls.Config) Option {
+	return func(cfg *config) { cfg.tlsConfig = tlsConfig }
+}
+
+// WithCompression sets the wire compression algorithm, e.g. "gzip". An
+// empty string (the default) disables compression.
+func WithCompression(compression string) Option {
+	return func(cfg *config) { cfg.compression = compression }
+}
+
+// WithRetryConfig overrides DefaultRetryConfig.
+func WithRetryConfig(retry RetryConfig) Option {
+	return func(cfg *config) { cfg.retry = retry }
+}
+
+// driver is the transport-specific half of the Exporter.
+type driver interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	ExportSpans(ctx context.Context, spans []ExportSpan) error
+	ExportMeasurements(ctx context.Context, measurements []ExportMeasurement) error
+}
+
+// ExportMeasurement pairs a Measurement with any Exemplars the
+// aggregation that produced it retained, so trace-to-metric correlation
+// survives the trip to the collector.
+type ExportMeasurement struct {
+	core.Measurement
+	Exemplars []exemplar.Exemplar
+}
+
+// ExportSpan is the span-end event shape the otlp exporter accepts.
+// Span construction and lifecycle live outside this package; Exporter
+// only knows how to serialize and ship a finished span.
+type ExportSpan struct {
+	SpanContext  core.SpanContext
+	ParentSpanID core.SpanID
+	Name         string
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   []core.KeyValue
+	StatusCode   string
+}
+
+// Exporter marshals spans and measurements into OTLP wire messages and
+// ships them to a collector via a batching Processor.
+type Exporter struct {
+	cfg       config
+	driver    driver
+	processor *Processor
+}
+
+// New creates an Exporter and starts its background batch Processor.
+// Callers must call Shutdown to flush pending data and release the
+// underlying connection.
+func New(ctx context.Context, opts ...Option) (*Exporter, error) {
+	cfg := newConfig(opts...)
+
+	var d driver
+	switch cfg.protocol {
+	case ProtocolHTTP:
+		d = newHTTPDriver(cfg)
+	default:
+		d = newGRPCDriver(cfg)
+	}
+
+	if err := d.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	e := &Exporter{
+		cfg:    cfg,
+		driver: d,
+	}
+	e.processor = newProcessor(d, cfg.retry)
+	return e, nil
+}
+
+// ExportSpans enqueues spans for asynchronous export.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []ExportSpan) {
+	e.processor.enqueueSpans(ctx, spans)
+}
+
+// ExportMeasurements enqueues measurements for asynchronous export.
+func (e *Exporter) ExportMeasurements(ctx context.Context, measurements []ExportMeasurement) {
+	e.processor.enqueueMeasurements(ctx, measurements)
+}
+
+// Shutdown flushes any queued data and closes the underlying
+// connection. It respects ctx's deadline/cancellation while flushing.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.processor.shutdown(ctx)
+	return e.driver.Stop(ctx)
+}
+
+// DroppedMeasurement returns a core.Measurement reporting the number of
+// batches dropped so far because the background queue was full, so
+// callers can feed it into their own metrics pipeline as an internal
+// health signal.
+func (e *Exporter) DroppedMeasurement() core.Measurement {
+	return exporterDropped.M(float64(e.processor.Dropped()))
+}
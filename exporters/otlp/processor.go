@@ -0,0 +1,169 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultQueueSize    = 2048
+	defaultBatchTimeout = 5 * time.Second
+)
+
+type batch struct {
+	spans        []ExportSpan
+	measurements []ExportMeasurement
+}
+
+// Processor batches spans and measurements in the background and hands
+// them to a driver, so callers never block on a network round trip.
+// Drops are counted rather than blocking producers once the queue is
+// full.
+type Processor struct {
+	driver  driver
+	retry   RetryConfig
+	queue   chan batch
+	done    chan struct{}
+	wg      sync.WaitGroup
+	dropped uint64
+}
+
+func newProcessor(d driver, retry RetryConfig) *Processor {
+	p := &Processor{
+		driver: d,
+		retry:  retry,
+		queue:  make(chan batch, defaultQueueSize),
+		done:   make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go p.run()
+	return p
+}
+
+func (p *Processor) enqueueSpans(ctx context.Context, spans []ExportSpan) {
+	p.enqueue(batch{spans: spans})
+}
+
+func (p *Processor) enqueueMeasurements(ctx context.Context, measurements []ExportMeasurement) {
+	p.enqueue(batch{measurements: measurements})
+}
+
+func (p *Processor) enqueue(b batch) {
+	select {
+	case p.queue <- b:
+	default:
+		atomic.AddUint64(&p.dropped, 1)
+	}
+}
+
+// Dropped returns the number of batches dropped because the queue was
+// full, exposed so callers can surface it as an internal metric.
+func (p *Processor) Dropped() uint64 {
+	return atomic.LoadUint64(&p.dropped)
+}
+
+func (p *Processor) run() {
+	defer p.wg.Done()
+	for {
+		select {
+		case b := <-p.queue:
+			p.export(b)
+		case <-p.done:
+			p.drain()
+			return
+		}
+	}
+}
+
+// drain flushes any batches left in the queue after shutdown has been
+// requested, without blocking on new arrivals.
+func (p *Processor) drain() {
+	for {
+		select {
+		case b := <-p.queue:
+			p.export(b)
+		default:
+			return
+		}
+	}
+}
+
+func (p *Processor) export(b batch) {
+	timeout := defaultBatchTimeout
+	if p.retry.Enabled && p.retry.MaxElapsedTime > timeout {
+		timeout = p.retry.MaxElapsedTime
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if len(b.spans) > 0 {
+		_ = p.retrying(ctx, func(ctx context.Context) error {
+			return p.driver.ExportSpans(ctx, b.spans)
+		})
+	}
+	if len(b.measurements) > 0 {
+		_ = p.retrying(ctx, func(ctx context.Context) error {
+			return p.driver.ExportMeasurements(ctx, b.measurements)
+		})
+	}
+}
+
+// retrying calls export once, and if it fails and p.retry is enabled,
+// keeps retrying with exponential backoff (capped at MaxInterval) until
+// it succeeds, ctx is done, or MaxElapsedTime has passed since the
+// first attempt.
+func (p *Processor) retrying(ctx context.Context, export func(context.Context) error) error {
+	err := export(ctx)
+	if err == nil || !p.retry.Enabled {
+		return err
+	}
+
+	deadline := time.Now().Add(p.retry.MaxElapsedTime)
+	backoff := p.retry.InitialInterval
+	for err != nil && time.Now().Before(deadline) {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		err = export(ctx)
+		if backoff *= 2; backoff > p.retry.MaxInterval {
+			backoff = p.retry.MaxInterval
+		}
+	}
+	return err
+}
+
+// shutdown signals the background goroutine to drain the queue and
+// waits for it to finish, honoring ctx's deadline.
+func (p *Processor) shutdown(ctx context.Context) {
+	close(p.done)
+	flushed := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(flushed)
+	}()
+	select {
+	case <-flushed:
+	case <-ctx.Done():
+	}
+}
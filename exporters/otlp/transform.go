@@ -0,0 +1,135 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/metric/exemplar"
+)
+
+// toAnyValue marshals a core.Value into its OTLP AnyValue wire form.
+func toAnyValue(v core.Value) *commonpb.AnyValue {
+	switch v.Type {
+	case core.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.Bool}}
+	case core.INT32, core.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.Int64}}
+	case core.UINT32, core.UINT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v.Uint64)}}
+	case core.FLOAT32, core.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.Float64}}
+	case core.STRING:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.String}}
+	case core.BYTES:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v.Bytes}}
+	case core.LAZY:
+		// OTLP has no lazy-value wire form; evaluate (and memoize) it
+		// now, at export time.
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	default:
+		return &commonpb.AnyValue{}
+	}
+}
+
+func toKeyValues(attrs []core.KeyValue) []*commonpb.KeyValue {
+	out := make([]*commonpb.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		out = append(out, &commonpb.KeyValue{
+			Key:   kv.Key.Name(),
+			Value: toAnyValue(kv.Value),
+		})
+	}
+	return out
+}
+
+// toSpan marshals an ExportSpan into its OTLP wire form.
+func toSpan(s ExportSpan) *tracepb.Span {
+	return &tracepb.Span{
+		TraceId:           s.SpanContext.TraceID[:],
+		SpanId:            s.SpanContext.SpanID[:],
+		ParentSpanId:      s.ParentSpanID[:],
+		TraceState:        s.SpanContext.TraceState.String(),
+		Name:              s.Name,
+		StartTimeUnixNano: uint64(s.StartTime.UnixNano()),
+		EndTimeUnixNano:   uint64(s.EndTime.UnixNano()),
+		Attributes:        toKeyValues(s.Attributes),
+		Status:            &tracepb.Status{Code: statusCode(s.StatusCode)},
+	}
+}
+
+func toSpans(spans []ExportSpan) []*tracepb.Span {
+	out := make([]*tracepb.Span, 0, len(spans))
+	for _, s := range spans {
+		out = append(out, toSpan(s))
+	}
+	return out
+}
+
+func statusCode(code string) tracepb.Status_StatusCode {
+	if code == core.GrpcCodeToString(0) {
+		return tracepb.Status_STATUS_CODE_OK
+	}
+	return tracepb.Status_STATUS_CODE_ERROR
+}
+
+// toNumberDataPoint marshals an ExportMeasurement into an OTLP gauge
+// data point, carrying along any Exemplars retained for it so a viewer
+// can jump from this point back to the trace that produced it.
+// Aggregation into sums/histograms happens upstream of this exporter;
+// here we only carry the raw recorded value.
+func toNumberDataPoint(m ExportMeasurement) *metricpb.NumberDataPoint {
+	return &metricpb.NumberDataPoint{
+		Value:        &metricpb.NumberDataPoint_AsDouble{AsDouble: m.Value},
+		TimeUnixNano: 0,
+		Exemplars:    toExemplars(m.Exemplars),
+	}
+}
+
+func toExemplars(exemplars []exemplar.Exemplar) []*metricpb.Exemplar {
+	out := make([]*metricpb.Exemplar, 0, len(exemplars))
+	for _, e := range exemplars {
+		out = append(out, &metricpb.Exemplar{
+			Value:              &metricpb.Exemplar_AsDouble{AsDouble: e.Value},
+			TimeUnixNano:       uint64(e.Timestamp.UnixNano()),
+			SpanId:             e.SpanContext.SpanID[:],
+			TraceId:            e.SpanContext.TraceID[:],
+			FilteredAttributes: toKeyValues(e.Attributes),
+		})
+	}
+	return out
+}
+
+func toMetric(m ExportMeasurement) *metricpb.Metric {
+	return &metricpb.Metric{
+		Name: m.Measure.Name(),
+		Data: &metricpb.Metric_Gauge{
+			Gauge: &metricpb.Gauge{
+				DataPoints: []*metricpb.NumberDataPoint{toNumberDataPoint(m)},
+			},
+		},
+	}
+}
+
+func toMetrics(measurements []ExportMeasurement) []*metricpb.Metric {
+	out := make([]*metricpb.Metric, 0, len(measurements))
+	for _, m := range measurements {
+		out = append(out, toMetric(m))
+	}
+	return out
+}
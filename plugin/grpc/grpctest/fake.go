@@ -0,0 +1,115 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpctest provides a fake TracerProvider that records the
+// spans started during a test, so plugin/grpc interceptors can be
+// asserted on without a real tracing SDK.
+package grpctest
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/trace"
+)
+
+// FakeSpan is a recorded, completed (or in-flight) span.
+type FakeSpan struct {
+	Name       string
+	Kind       trace.SpanKind
+	Attributes []core.KeyValue
+	Status     codes.Code
+	Ended      bool
+}
+
+// FakeTracerProvider is a trace.TracerProvider that records every span
+// started by any of its Tracers.
+type FakeTracerProvider struct {
+	mu    sync.Mutex
+	spans []*FakeSpan
+}
+
+var _ trace.TracerProvider = (*FakeTracerProvider)(nil)
+
+// NewFakeTracerProvider returns an empty FakeTracerProvider.
+func NewFakeTracerProvider() *FakeTracerProvider {
+	return &FakeTracerProvider{}
+}
+
+// Tracer returns a Tracer that records its spans on p.
+func (p *FakeTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &fakeTracer{provider: p}
+}
+
+// Spans returns a snapshot of every span started so far, in start
+// order.
+func (p *FakeTracerProvider) Spans() []*FakeSpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]*FakeSpan, len(p.spans))
+	copy(out, p.spans)
+	return out
+}
+
+func (p *FakeTracerProvider) record(span *FakeSpan) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.spans = append(p.spans, span)
+}
+
+type fakeTracer struct {
+	provider *FakeTracerProvider
+}
+
+func (t *fakeTracer) Start(ctx context.Context, spanName string, opts ...trace.StartOption) (context.Context, trace.Span) {
+	var cfg trace.StartConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	span := &fakeSpan{
+		FakeSpan: &FakeSpan{
+			Name:       spanName,
+			Kind:       cfg.SpanKind,
+			Attributes: cfg.Attributes,
+		},
+	}
+	t.provider.record(span.FakeSpan)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type fakeSpan struct {
+	*FakeSpan
+}
+
+func (s *fakeSpan) End(...trace.EndOption) { s.Ended = true }
+
+func (s *fakeSpan) Tracer() trace.Tracer { return nil }
+
+func (s *fakeSpan) AddEvent(context.Context, string, ...core.KeyValue) {}
+
+func (s *fakeSpan) SetStatus(code codes.Code) { s.Status = code }
+
+func (s *fakeSpan) SetName(name string) { s.Name = name }
+
+func (s *fakeSpan) SetAttributes(attrs ...core.KeyValue) {
+	s.Attributes = append(s.Attributes, attrs...)
+}
+
+func (s *fakeSpan) SpanContext() core.SpanContext { return core.INVALID_SPAN_CONTEXT }
+
+func (s *fakeSpan) IsRecording() bool { return !s.Ended }
@@ -0,0 +1,90 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/plugin/grpc/grpctest"
+)
+
+// fakeMessage is a minimal proto.Message so recordSizes has something
+// to size; it carries no fields, so proto.Size always reports 0, but
+// the type assertion path that wires rpcRequestSize/rpcResponseSize is
+// still exercised.
+type fakeMessage struct{}
+
+func (*fakeMessage) Reset()         {}
+func (*fakeMessage) String() string { return "" }
+func (*fakeMessage) ProtoMessage()  {}
+
+func TestUnaryServerInterceptorRecordsSpanAndMeasurements(t *testing.T) {
+	provider := grpctest.NewFakeTracerProvider()
+
+	var measurements []core.Measurement
+	recorder := WithRecorder(func(m core.Measurement) { measurements = append(measurements, m) })
+
+	interceptor := UnaryServerInterceptor(provider, recorder)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Echo/Say"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &fakeMessage{}, nil
+	}
+
+	_, err := interceptor(context.Background(), &fakeMessage{}, info, handler)
+	if err != nil {
+		t.Fatalf("interceptor returned error: %v", err)
+	}
+
+	spans := provider.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != info.FullMethod {
+		t.Errorf("span name = %q, want %q", span.Name, info.FullMethod)
+	}
+	if !span.Ended {
+		t.Error("span was not ended")
+	}
+	if span.Status != codes.OK {
+		t.Errorf("span status = %v, want %v", span.Status, codes.OK)
+	}
+
+	var sawDuration, sawRequestSize, sawResponseSize bool
+	for _, m := range measurements {
+		switch m.Measure.Name() {
+		case rpcServerDuration.Name():
+			sawDuration = true
+		case rpcRequestSize.Name():
+			sawRequestSize = true
+		case rpcResponseSize.Name():
+			sawResponseSize = true
+		}
+	}
+	if !sawDuration {
+		t.Error("no rpc.server.duration measurement recorded")
+	}
+	if !sawRequestSize {
+		t.Error("no rpc.request.size measurement recorded")
+	}
+	if !sawResponseSize {
+		t.Error("no rpc.response.size measurement recorded")
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"github.com/open-telemetry/opentelemetry-go/api/core"
+	"github.com/open-telemetry/opentelemetry-go/api/measure"
+	"github.com/open-telemetry/opentelemetry-go/api/unit"
+)
+
+// rpcServerDuration records the duration of an RPC, in milliseconds,
+// keyed by the OpenTelemetry semantic convention name. Every
+// interceptor in this package records into it, so client-side call
+// durations are reported under this same name alongside server ones.
+var rpcServerDuration = measure.New("rpc.server.duration", "measures the duration of RPCs seen by the interceptors", unit.Milliseconds)
+
+// rpcRequestSize and rpcResponseSize record the wire size of a unary
+// RPC's request and response messages, in bytes. Only unary calls have
+// a single well-defined request/response to size; streaming RPCs
+// exchange an arbitrary number of messages and are not measured here.
+var (
+	rpcRequestSize  = measure.New("rpc.request.size", "measures the size of RPC request messages", unit.Bytes)
+	rpcResponseSize = measure.New("rpc.response.size", "measures the size of RPC response messages", unit.Bytes)
+)
+
+// Recorder is the sink every Measurement produced by this package's
+// interceptors is handed to. Pass one via WithRecorder to feed RPC
+// duration and size Measurements into a metrics pipeline; without it
+// they are silently discarded, since this package does not depend on a
+// particular metrics SDK.
+type Recorder func(core.Measurement)
+
+func defaultRecorder(core.Measurement) {}
@@ -0,0 +1,67 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-go/api/trace"
+)
+
+// options holds the instrumentation configuration shared by the
+// interceptor constructors in this package.
+type options struct {
+	recorder Recorder
+}
+
+func newOptions(opts []Option) options {
+	o := options{recorder: defaultRecorder}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Option configures the interceptors returned by UnaryServerInterceptor,
+// UnaryClientInterceptor, StreamServerInterceptor, StreamClientInterceptor,
+// ServerOptions, and DialOptions.
+type Option func(*options)
+
+// WithRecorder sets the Recorder that RPC duration/request-size/
+// response-size Measurements are handed to. Without this option they
+// are discarded.
+func WithRecorder(r Recorder) Option {
+	return func(o *options) { o.recorder = r }
+}
+
+// ServerOptions returns the grpc.ServerOptions needed to instrument a
+// server with both unary and streaming interceptors, for use in
+// grpc.NewServer(ServerOptions(tp)...).
+func ServerOptions(tp trace.TracerProvider, opts ...Option) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(UnaryServerInterceptor(tp, opts...)),
+		grpc.StreamInterceptor(StreamServerInterceptor(tp, opts...)),
+	}
+}
+
+// DialOptions returns the grpc.DialOptions needed to instrument a
+// client with both unary and streaming interceptors, for use in
+// grpc.Dial(target, DialOptions(tp)...).
+func DialOptions(tp trace.TracerProvider, opts ...Option) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(tp, opts...)),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(tp, opts...)),
+	}
+}